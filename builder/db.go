@@ -14,17 +14,121 @@ type DBTX interface {
 	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
 }
 
-// SelectForQuery wraps DBTX and overrides only the matching sqlc query.
-// If expectedQuery is empty, all QueryContext/QueryRowContext calls are overridden.
-func SelectForQuery(raw DBTX, expectedQuery string, sb sq.SelectBuilder) DBTX {
-	return &selector{
-		raw:           raw,
+// Option configures a DBTX wrapper returned by SelectForQuery or Select.
+type Option func(*selector)
+
+// WithPlaceholderFormat rebinds the `?` placeholders produced by sb.ToSql()
+// into pf's dialect before the query reaches raw. Without this option the
+// package-level Default is used.
+func WithPlaceholderFormat(pf PlaceholderFormat) Option {
+	return func(s *selector) {
+		s.pf = pf
+	}
+}
+
+// WithInExpansion opts a SelectForQuery/Select wrapper into expanding any
+// `?` placeholder whose builder-supplied arg is a slice (e.g. from
+// sb.Where("id IN (?)", ids)) into the right number of `?` placeholders
+// via ExpandIn, before the dialect's PlaceholderFormat is applied. Without
+// this option a slice arg is passed straight to the driver, which will
+// reject it.
+func WithInExpansion() Option {
+	return func(s *selector) {
+		s.expandIn = true
+	}
+}
+
+// selectInterceptor builds the Interceptor that SelectForQuery applies
+// over raw: SelectForQuery is just Use(raw, selectInterceptor(...)).
+func selectInterceptor(expectedQuery string, sb sq.SelectBuilder, opts ...Option) Interceptor {
+	s := &selector{
 		expectedQuery: expectedQuery,
 		sb:            sb,
+		pf:            Default,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return func(next DBTX) DBTX {
+		s.raw = next
+		return s
+	}
+}
+
+// SelectForQuery wraps DBTX and overrides only the matching sqlc query.
+// If expectedQuery is empty, all QueryContext/QueryRowContext calls are overridden.
+func SelectForQuery(raw DBTX, expectedQuery string, sb sq.SelectBuilder, opts ...Option) DBTX {
+	return Use(raw, selectInterceptor(expectedQuery, sb, opts...))
 }
 
-func Select(raw DBTX, sb sq.SelectBuilder) DBTX {
+func Select(raw DBTX, sb sq.SelectBuilder, opts ...Option) DBTX {
 	// Backwards-compatible behavior: override all query calls.
-	return SelectForQuery(raw, "", sb)
+	return SelectForQuery(raw, "", sb, opts...)
+}
+
+// ExecOption configures a DBTX wrapper returned by UpdateForQuery,
+// DeleteForQuery, or InsertForQuery.
+type ExecOption func(*execer)
+
+// WithExecPlaceholderFormat rebinds the `?` placeholders produced by
+// eb.ToSql() into pf's dialect before the query reaches raw. Without this
+// option the package-level Default is used.
+func WithExecPlaceholderFormat(pf PlaceholderFormat) ExecOption {
+	return func(e *execer) {
+		e.pf = pf
+	}
+}
+
+// execInterceptor builds the Interceptor that newExecer applies over raw:
+// newExecer is just Use(raw, execInterceptor(...)).
+func execInterceptor(expectedQuery string, eb execBuilder, opts []ExecOption) Interceptor {
+	e := &execer{
+		expectedQuery: expectedQuery,
+		eb:            eb,
+		pf:            Default,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return func(next DBTX) DBTX {
+		e.raw = next
+		return e
+	}
+}
+
+func newExecer(raw DBTX, expectedQuery string, eb execBuilder, opts []ExecOption) DBTX {
+	return Use(raw, execInterceptor(expectedQuery, eb, opts))
+}
+
+// UpdateForQuery wraps DBTX and overrides only the matching sqlc query's
+// ExecContext call with the SQL/args produced by ub.
+func UpdateForQuery(raw DBTX, expectedQuery string, ub sq.UpdateBuilder, opts ...ExecOption) DBTX {
+	return newExecer(raw, expectedQuery, ub, opts)
+}
+
+// Update overrides every ExecContext call with the SQL/args produced by ub.
+func Update(raw DBTX, ub sq.UpdateBuilder, opts ...ExecOption) DBTX {
+	return UpdateForQuery(raw, "", ub, opts...)
+}
+
+// DeleteForQuery wraps DBTX and overrides only the matching sqlc query's
+// ExecContext call with the SQL/args produced by db.
+func DeleteForQuery(raw DBTX, expectedQuery string, db sq.DeleteBuilder, opts ...ExecOption) DBTX {
+	return newExecer(raw, expectedQuery, db, opts)
+}
+
+// Delete overrides every ExecContext call with the SQL/args produced by db.
+func Delete(raw DBTX, db sq.DeleteBuilder, opts ...ExecOption) DBTX {
+	return DeleteForQuery(raw, "", db, opts...)
+}
+
+// InsertForQuery wraps DBTX and overrides only the matching sqlc query's
+// ExecContext call with the SQL/args produced by ib.
+func InsertForQuery(raw DBTX, expectedQuery string, ib sq.InsertBuilder, opts ...ExecOption) DBTX {
+	return newExecer(raw, expectedQuery, ib, opts)
+}
+
+// Insert overrides every ExecContext call with the SQL/args produced by ib.
+func Insert(raw DBTX, ib sq.InsertBuilder, opts ...ExecOption) DBTX {
+	return InsertForQuery(raw, "", ib, opts...)
 }
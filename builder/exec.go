@@ -0,0 +1,54 @@
+package builder
+
+import (
+	"context"
+	"database/sql"
+)
+
+// execBuilder is the subset of squirrel's UpdateBuilder/DeleteBuilder/
+// InsertBuilder that execer needs to turn an intercepted ExecContext call
+// into SQL.
+type execBuilder interface {
+	ToSql() (string, []interface{}, error)
+}
+
+// execer mirrors selector, but intercepts ExecContext instead of
+// QueryContext/QueryRowContext, for dynamic UPDATE/DELETE/INSERT.
+type execer struct {
+	raw           DBTX
+	expectedQuery string
+	eb            execBuilder
+	pf            PlaceholderFormat
+}
+
+func (r *execer) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	// Prepared statements are intentionally not intercepted:
+	// the builder's placeholder args are not available at stmt exec time.
+	return r.raw.PrepareContext(ctx, query)
+}
+
+func (r *execer) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if r.expectedQuery != "" && query != r.expectedQuery {
+		return r.raw.ExecContext(ctx, query, args...)
+	}
+
+	overriddenQuery, overriddenArgs, err := r.eb.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	overriddenQuery, err = r.pf.Rebind(overriddenQuery)
+	if err != nil {
+		return nil, err
+	}
+	return r.raw.ExecContext(ctx, overriddenQuery, overriddenArgs...)
+}
+
+func (r *execer) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	// This wrapper is intended for Exec interception; keep Query passthrough.
+	return r.raw.QueryContext(ctx, query, args...)
+}
+
+func (r *execer) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	// This wrapper is intended for Exec interception; keep Query passthrough.
+	return r.raw.QueryRowContext(ctx, query, args...)
+}
@@ -0,0 +1,118 @@
+package builder
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type recordExecDB struct {
+	lastQuery string
+	lastArgs  []interface{}
+}
+
+func (r *recordExecDB) PrepareContext(context.Context, string) (*sql.Stmt, error) { return nil, nil }
+func (r *recordExecDB) ExecContext(_ context.Context, query string, args ...interface{}) (sql.Result, error) {
+	r.lastQuery = query
+	r.lastArgs = append([]interface{}(nil), args...)
+	return nil, nil
+}
+func (r *recordExecDB) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	return nil, nil
+}
+func (r *recordExecDB) QueryRowContext(context.Context, string, ...interface{}) *sql.Row { return nil }
+
+func TestUpdateForQuery_OnlyOverridesMatchingQuery(t *testing.T) {
+	ctx := context.Background()
+	raw := &recordExecDB{}
+
+	ub := sq.Update("users").Set("name", "bob").Where(sq.Eq{"id": 10})
+	wrapped := UpdateForQuery(raw, "expected", ub)
+
+	// Non-matching query should pass through unchanged.
+	if _, err := wrapped.ExecContext(ctx, "other", 1, 2, 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if raw.lastQuery != "other" {
+		t.Fatalf("query = %q, want %q", raw.lastQuery, "other")
+	}
+	if !reflect.DeepEqual(raw.lastArgs, []interface{}{1, 2, 3}) {
+		t.Fatalf("args = %#v, want %#v", raw.lastArgs, []interface{}{1, 2, 3})
+	}
+
+	// Matching query should be overridden by the builder SQL.
+	if _, err := wrapped.ExecContext(ctx, "expected"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantQuery, wantArgs, err := ub.ToSql()
+	if err != nil {
+		t.Fatalf("ub.ToSql() error: %v", err)
+	}
+	if raw.lastQuery != wantQuery {
+		t.Fatalf("query = %q, want %q", raw.lastQuery, wantQuery)
+	}
+	if !reflect.DeepEqual(raw.lastArgs, wantArgs) {
+		t.Fatalf("args = %#v, want %#v", raw.lastArgs, wantArgs)
+	}
+}
+
+func TestDeleteForQuery_OnlyOverridesMatchingQuery(t *testing.T) {
+	ctx := context.Background()
+	raw := &recordExecDB{}
+
+	db := sq.Delete("users").Where(sq.Eq{"id": 10})
+	wrapped := DeleteForQuery(raw, "expected", db)
+
+	if _, err := wrapped.ExecContext(ctx, "other"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if raw.lastQuery != "other" {
+		t.Fatalf("query = %q, want %q", raw.lastQuery, "other")
+	}
+
+	if _, err := wrapped.ExecContext(ctx, "expected"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantQuery, wantArgs, err := db.ToSql()
+	if err != nil {
+		t.Fatalf("db.ToSql() error: %v", err)
+	}
+	if raw.lastQuery != wantQuery {
+		t.Fatalf("query = %q, want %q", raw.lastQuery, wantQuery)
+	}
+	if !reflect.DeepEqual(raw.lastArgs, wantArgs) {
+		t.Fatalf("args = %#v, want %#v", raw.lastArgs, wantArgs)
+	}
+}
+
+func TestInsertForQuery_OnlyOverridesMatchingQuery(t *testing.T) {
+	ctx := context.Background()
+	raw := &recordExecDB{}
+
+	ib := sq.Insert("users").Columns("name", "age").Values("bob", 42)
+	wrapped := InsertForQuery(raw, "expected", ib)
+
+	if _, err := wrapped.ExecContext(ctx, "other"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if raw.lastQuery != "other" {
+		t.Fatalf("query = %q, want %q", raw.lastQuery, "other")
+	}
+
+	if _, err := wrapped.ExecContext(ctx, "expected"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantQuery, wantArgs, err := ib.ToSql()
+	if err != nil {
+		t.Fatalf("ib.ToSql() error: %v", err)
+	}
+	if raw.lastQuery != wantQuery {
+		t.Fatalf("query = %q, want %q", raw.lastQuery, wantQuery)
+	}
+	if !reflect.DeepEqual(raw.lastArgs, wantArgs) {
+		t.Fatalf("args = %#v, want %#v", raw.lastArgs, wantArgs)
+	}
+}
@@ -0,0 +1,136 @@
+package builder
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// ExpandIn scans query for positional `?` placeholders (outside quoted
+// strings and dollar-quoted bodies) and expands any one whose
+// corresponding arg is a slice or array into that many `?` placeholders,
+// flattening the slice into the returned args. It borrows the idea from
+// sqlx.In, but stays placeholder-syntax agnostic so its output can still
+// be rebound to any dialect by a PlaceholderFormat afterward.
+func ExpandIn(query string, args ...interface{}) (string, []interface{}, error) {
+	var buf strings.Builder
+	buf.Grow(len(query))
+	outArgs := make([]interface{}, 0, len(args))
+
+	argIdx := 0
+	for i := 0; i < len(query); {
+		switch c := query[i]; c {
+		case '\'', '"', '`':
+			end := skipQuoted(query, i, c)
+			buf.WriteString(query[i:end])
+			i = end
+		case '$':
+			if end, ok := skipDollarQuoted(query, i); ok {
+				buf.WriteString(query[i:end])
+				i = end
+				continue
+			}
+			buf.WriteByte(c)
+			i++
+		case '?':
+			if argIdx >= len(args) {
+				return "", nil, fmt.Errorf("builder: ExpandIn: query has more `?` placeholders than the %d args given", len(args))
+			}
+			arg := args[argIdx]
+			argIdx++
+
+			vals, isSlice := sliceValues(arg)
+			if !isSlice {
+				buf.WriteByte('?')
+				outArgs = append(outArgs, arg)
+				i++
+				continue
+			}
+			if len(vals) == 0 {
+				return "", nil, fmt.Errorf("builder: ExpandIn: empty slice for placeholder %d", argIdx)
+			}
+			for j, v := range vals {
+				if j > 0 {
+					buf.WriteString(", ")
+				}
+				buf.WriteByte('?')
+				outArgs = append(outArgs, v)
+			}
+			i++
+		default:
+			buf.WriteByte(c)
+			i++
+		}
+	}
+
+	if argIdx != len(args) {
+		return "", nil, fmt.Errorf("builder: ExpandIn: %d args given but query only has %d `?` placeholders", len(args), argIdx)
+	}
+	return buf.String(), outArgs, nil
+}
+
+// sliceValues returns the elements of arg if it's a slice or array other
+// than []byte (which is a single driver value, not a set of them), and
+// reports whether arg was such a slice.
+func sliceValues(arg interface{}) (vals []interface{}, ok bool) {
+	if _, isBytes := arg.([]byte); isBytes {
+		return nil, false
+	}
+	v := reflect.ValueOf(arg)
+	if !v.IsValid() || (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) {
+		return nil, false
+	}
+	vals = make([]interface{}, v.Len())
+	for i := range vals {
+		vals[i] = v.Index(i).Interface()
+	}
+	return vals, true
+}
+
+// NamedIn builds a condition equivalent to ANDing together sq.Eq(columns)
+// entries, except that a column bound to an empty slice is rewritten to
+// the literal condition "1=0" instead of relying on whatever a given
+// sq.Eq/driver combination does with an empty IN list. Unlike sq.And, the
+// result isn't wrapped in an extra pair of parentheses, so a single-column
+// call renders identically to the bare sq.Eq it wraps. Columns are visited
+// in sorted key order, like squirrel's own Eq.ToSql, so the same input
+// always renders the same SQL.
+func NamedIn(columns map[string]interface{}) sq.Sqlizer {
+	cols := make([]string, 0, len(columns))
+	for col := range columns {
+		cols = append(cols, col)
+	}
+	sort.Strings(cols)
+
+	parts := make(andParts, 0, len(cols))
+	for _, col := range cols {
+		val := columns[col]
+		if vals, ok := sliceValues(val); ok && len(vals) == 0 {
+			parts = append(parts, sq.Expr("1=0"))
+			continue
+		}
+		parts = append(parts, sq.Eq{col: val})
+	}
+	return parts
+}
+
+// andParts joins its parts with " AND ", unlike sq.And which also wraps
+// the joined result in parentheses.
+type andParts []sq.Sqlizer
+
+func (p andParts) ToSql() (string, []interface{}, error) {
+	sqls := make([]string, len(p))
+	var args []interface{}
+	for i, part := range p {
+		s, a, err := part.ToSql()
+		if err != nil {
+			return "", nil, err
+		}
+		sqls[i] = s
+		args = append(args, a...)
+	}
+	return strings.Join(sqls, " AND "), args, nil
+}
@@ -0,0 +1,180 @@
+package builder
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+func TestExpandIn(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		args     []interface{}
+		wantSQL  string
+		wantArgs []interface{}
+		wantErr  bool
+	}{
+		{
+			name:     "no slices is a no-op",
+			query:    "SELECT * FROM t WHERE a = ? AND b = ?",
+			args:     []interface{}{1, "x"},
+			wantSQL:  "SELECT * FROM t WHERE a = ? AND b = ?",
+			wantArgs: []interface{}{1, "x"},
+		},
+		{
+			name:     "slice arg expands to one placeholder per element",
+			query:    "SELECT * FROM t WHERE id IN (?) AND b = ?",
+			args:     []interface{}{[]int{1, 2, 3}, "x"},
+			wantSQL:  "SELECT * FROM t WHERE id IN (?, ?, ?) AND b = ?",
+			wantArgs: []interface{}{1, 2, 3, "x"},
+		},
+		{
+			name:     "[]byte is left as a single arg",
+			query:    "SELECT * FROM t WHERE blob = ?",
+			args:     []interface{}{[]byte("raw")},
+			wantSQL:  "SELECT * FROM t WHERE blob = ?",
+			wantArgs: []interface{}{[]byte("raw")},
+		},
+		{
+			name:     "question mark in a quoted literal is untouched",
+			query:    "SELECT * FROM t WHERE id IN (?) AND note = 'is this ok?'",
+			args:     []interface{}{[]int{1, 2}},
+			wantSQL:  "SELECT * FROM t WHERE id IN (?, ?) AND note = 'is this ok?'",
+			wantArgs: []interface{}{1, 2},
+		},
+		{
+			name:    "empty slice is an error",
+			query:   "SELECT * FROM t WHERE id IN (?)",
+			args:    []interface{}{[]int{}},
+			wantErr: true,
+		},
+		{
+			name:    "too few args",
+			query:   "SELECT * FROM t WHERE a = ?",
+			args:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "too many args",
+			query:   "SELECT * FROM t",
+			args:    []interface{}{1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotArgs, err := ExpandIn(tt.query, tt.args...)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ExpandIn() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExpandIn() error = %v", err)
+			}
+			if gotSQL != tt.wantSQL {
+				t.Fatalf("sql = %q, want %q", gotSQL, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Fatalf("args = %#v, want %#v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestExpandIn_ThenDialectRebind(t *testing.T) {
+	sql, args, err := ExpandIn("SELECT * FROM t WHERE id IN (?) AND b = ?", []int{1, 2, 3}, "x")
+	if err != nil {
+		t.Fatalf("ExpandIn() error: %v", err)
+	}
+	sql, err = Dollar.Rebind(sql)
+	if err != nil {
+		t.Fatalf("Rebind() error: %v", err)
+	}
+	wantSQL := "SELECT * FROM t WHERE id IN ($1, $2, $3) AND b = $4"
+	if sql != wantSQL {
+		t.Fatalf("sql = %q, want %q", sql, wantSQL)
+	}
+	wantArgs := []interface{}{1, 2, 3, "x"}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Fatalf("args = %#v, want %#v", args, wantArgs)
+	}
+}
+
+func TestNamedIn(t *testing.T) {
+	t.Run("non-empty slice behaves like sq.Eq", func(t *testing.T) {
+		sql, args, err := NamedIn(map[string]interface{}{"id": []int{1, 2, 3}}).ToSql()
+		if err != nil {
+			t.Fatalf("ToSql() error: %v", err)
+		}
+		want := "id IN (?,?,?)"
+		if sql != want {
+			t.Fatalf("sql = %q, want %q", sql, want)
+		}
+		if !reflect.DeepEqual(args, []interface{}{1, 2, 3}) {
+			t.Fatalf("args = %#v, want %#v", args, []interface{}{1, 2, 3})
+		}
+	})
+
+	t.Run("empty slice rewrites to 1=0", func(t *testing.T) {
+		sql, args, err := NamedIn(map[string]interface{}{"id": []int{}}).ToSql()
+		if err != nil {
+			t.Fatalf("ToSql() error: %v", err)
+		}
+		if sql != "1=0" {
+			t.Fatalf("sql = %q, want %q", sql, "1=0")
+		}
+		if len(args) != 0 {
+			t.Fatalf("args = %#v, want empty", args)
+		}
+	})
+
+	t.Run("multiple columns render in a deterministic, sorted order", func(t *testing.T) {
+		columns := map[string]interface{}{
+			"zeta":  []int{1, 2},
+			"alpha": []int{3, 4},
+			"mid":   []int{},
+		}
+		wantSQL := "alpha IN (?,?) AND 1=0 AND zeta IN (?,?)"
+		wantArgs := []interface{}{3, 4, 1, 2}
+
+		for i := 0; i < 3; i++ {
+			sql, args, err := NamedIn(columns).ToSql()
+			if err != nil {
+				t.Fatalf("ToSql() error: %v", err)
+			}
+			if sql != wantSQL {
+				t.Fatalf("sql = %q, want %q", sql, wantSQL)
+			}
+			if !reflect.DeepEqual(args, wantArgs) {
+				t.Fatalf("args = %#v, want %#v", args, wantArgs)
+			}
+		}
+	})
+}
+
+func TestWithInExpansion(t *testing.T) {
+	ctx := context.Background()
+	raw := &recordDB{}
+
+	sb := sq.Select("*").From("users").Where("id IN (?)", []int{1, 2, 3})
+	wrapped := SelectForQuery(raw, "", sb, WithInExpansion(), WithPlaceholderFormat(Dollar))
+
+	if _, err := wrapped.QueryContext(ctx, "expected"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantQuery := "SELECT * FROM users WHERE id IN ($1, $2, $3)"
+	if raw.lastQuery != wantQuery {
+		t.Fatalf("query = %q, want %q", raw.lastQuery, wantQuery)
+	}
+	wantArgs := []interface{}{1, 2, 3}
+	if !reflect.DeepEqual(raw.lastArgs, wantArgs) {
+		t.Fatalf("args = %#v, want %#v", raw.lastArgs, wantArgs)
+	}
+}
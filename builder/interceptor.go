@@ -0,0 +1,157 @@
+package builder
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Interceptor wraps a DBTX with additional behavior and returns the
+// wrapped DBTX.
+type Interceptor func(next DBTX) DBTX
+
+// Use builds a DBTX pipeline by applying interceptors over raw, outermost
+// first: the first Interceptor in the list sees a call before any of the
+// others. SelectForQuery, Select, UpdateForQuery, and friends are
+// themselves implemented as interceptors internally, so they compose with
+// any other interceptor passed to Use.
+func Use(raw DBTX, interceptors ...Interceptor) DBTX {
+	db := raw
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		db = interceptors[i](db)
+	}
+	return db
+}
+
+// Hook observes a DBTX call before it runs and is notified of the result
+// once it completes, without having to implement DBTX itself.
+type Hook interface {
+	// OnQuery is called before query runs, for op "prepare", "exec",
+	// "query", or "queryRow". It returns a (possibly replaced) context
+	// to use for the call and a callback invoked with the call's error
+	// once it completes (nil for QueryRowContext, which has none).
+	OnQuery(ctx context.Context, op, query string, args []interface{}) (context.Context, func(err error))
+}
+
+// HookFunc adapts a plain function to a Hook.
+type HookFunc func(ctx context.Context, op, query string, args []interface{}) (context.Context, func(err error))
+
+func (f HookFunc) OnQuery(ctx context.Context, op, query string, args []interface{}) (context.Context, func(err error)) {
+	return f(ctx, op, query, args)
+}
+
+// WithHook turns a Hook into an Interceptor, applying it uniformly across
+// Prepare/Exec/Query/QueryRow.
+func WithHook(h Hook) Interceptor {
+	return func(next DBTX) DBTX {
+		return &hooked{next: next, hook: h}
+	}
+}
+
+type hooked struct {
+	next DBTX
+	hook Hook
+}
+
+func (h *hooked) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	ctx, done := h.hook.OnQuery(ctx, "prepare", query, nil)
+	stmt, err := h.next.PrepareContext(ctx, query)
+	done(err)
+	return stmt, err
+}
+
+func (h *hooked) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, done := h.hook.OnQuery(ctx, "exec", query, args)
+	res, err := h.next.ExecContext(ctx, query, args...)
+	done(err)
+	return res, err
+}
+
+func (h *hooked) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, done := h.hook.OnQuery(ctx, "query", query, args)
+	rows, err := h.next.QueryContext(ctx, query, args...)
+	done(err)
+	return rows, err
+}
+
+func (h *hooked) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx, done := h.hook.OnQuery(ctx, "queryRow", query, args)
+	row := h.next.QueryRowContext(ctx, query, args...)
+	done(nil)
+	return row
+}
+
+// Logger is the subset of structured logging methods WithLogger needs;
+// the standard library's *log.Logger satisfies it.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+// WithLogger logs every intercepted call's operation, query, args,
+// duration, and error via logger.
+func WithLogger(logger Logger) Interceptor {
+	return WithHook(HookFunc(func(ctx context.Context, op, query string, args []interface{}) (context.Context, func(error)) {
+		start := time.Now()
+		return ctx, func(err error) {
+			logger.Printf("builder: %s %q args=%v duration=%s err=%v", op, query, args, time.Since(start), err)
+		}
+	}))
+}
+
+// Tracer starts a span around an intercepted call and returns a func that
+// ends it. It's intentionally minimal so it's easy to adapt an
+// OpenTelemetry trace.Tracer: call tracer.Start and return span.End.
+type Tracer interface {
+	Start(ctx context.Context, spanName string) (context.Context, func())
+}
+
+// WithTracing starts a span via tracer around every intercepted call,
+// named "builder.<op>".
+func WithTracing(tracer Tracer) Interceptor {
+	return WithHook(HookFunc(func(ctx context.Context, op, query string, args []interface{}) (context.Context, func(error)) {
+		ctx, end := tracer.Start(ctx, "builder."+op)
+		return ctx, func(error) { end() }
+	}))
+}
+
+// WithSlowQuery calls onSlow with the operation, query, and elapsed time
+// whenever an intercepted call takes at least threshold to complete.
+func WithSlowQuery(threshold time.Duration, onSlow func(op, query string, d time.Duration)) Interceptor {
+	return WithHook(HookFunc(func(ctx context.Context, op, query string, args []interface{}) (context.Context, func(error)) {
+		start := time.Now()
+		return ctx, func(error) {
+			if d := time.Since(start); d >= threshold {
+				onSlow(op, query, d)
+			}
+		}
+	}))
+}
+
+// WithQueryRewriter rewrites query text before it reaches the wrapped
+// DBTX, e.g. to inject hints or strip sqlc's `-- name: ...` header.
+func WithQueryRewriter(rewrite func(query string) string) Interceptor {
+	return func(next DBTX) DBTX {
+		return &rewriter{next: next, rewrite: rewrite}
+	}
+}
+
+type rewriter struct {
+	next    DBTX
+	rewrite func(string) string
+}
+
+func (r *rewriter) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return r.next.PrepareContext(ctx, r.rewrite(query))
+}
+
+func (r *rewriter) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return r.next.ExecContext(ctx, r.rewrite(query), args...)
+}
+
+func (r *rewriter) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return r.next.QueryContext(ctx, r.rewrite(query), args...)
+}
+
+func (r *rewriter) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return r.next.QueryRowContext(ctx, r.rewrite(query), args...)
+}
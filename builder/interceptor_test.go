@@ -0,0 +1,106 @@
+package builder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type noopDB struct{}
+
+func (noopDB) PrepareContext(context.Context, string) (*sql.Stmt, error) { return nil, nil }
+func (noopDB) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	return nil, nil
+}
+func (noopDB) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	return nil, nil
+}
+func (noopDB) QueryRowContext(context.Context, string, ...interface{}) *sql.Row { return nil }
+
+func TestUse_AppliesOutermostFirst(t *testing.T) {
+	var order []string
+	trace := func(name string) Interceptor {
+		return WithHook(HookFunc(func(ctx context.Context, op, query string, args []interface{}) (context.Context, func(error)) {
+			order = append(order, name)
+			return ctx, func(error) {}
+		}))
+	}
+
+	db := Use(noopDB{}, trace("outer"), trace("inner"))
+	if _, err := db.ExecContext(context.Background(), "q"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"outer", "inner"}; !equal(order, want) {
+		t.Fatalf("call order = %v, want %v", order, want)
+	}
+}
+
+func TestWithQueryRewriter(t *testing.T) {
+	var gotQuery string
+	recorder := HookFunc(func(ctx context.Context, op, query string, args []interface{}) (context.Context, func(error)) {
+		gotQuery = query
+		return ctx, func(error) {}
+	})
+
+	db := Use(noopDB{}, WithQueryRewriter(func(q string) string {
+		return q + " /* rewritten */"
+	}), WithHook(recorder))
+	if _, err := db.ExecContext(context.Background(), "SELECT 1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "SELECT 1 /* rewritten */"; gotQuery != want {
+		t.Fatalf("query = %q, want %q", gotQuery, want)
+	}
+}
+
+func TestWithSlowQuery(t *testing.T) {
+	var slowOp, slowQuery string
+	db := Use(noopDB{}, WithSlowQuery(0, func(op, query string, d time.Duration) {
+		slowOp, slowQuery = op, query
+	}))
+	if _, err := db.ExecContext(context.Background(), "SELECT 1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if slowOp != "exec" || slowQuery != "SELECT 1" {
+		t.Fatalf("onSlow called with (%q, %q), want (\"exec\", \"SELECT 1\")", slowOp, slowQuery)
+	}
+}
+
+func TestWithLogger_ReceivesError(t *testing.T) {
+	var logged string
+	db := Use(erroringDB{}, WithLogger(loggerFunc(func(format string, args ...interface{}) {
+		logged = fmt.Sprintf(format, args...)
+	})))
+	if _, err := db.ExecContext(context.Background(), "SELECT 1"); err == nil {
+		t.Fatalf("expected error")
+	}
+	if !strings.Contains(logged, "err=boom") {
+		t.Fatalf("log line = %q, want it to contain %q", logged, "err=boom")
+	}
+}
+
+type erroringDB struct{ noopDB }
+
+func (erroringDB) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	return nil, errors.New("boom")
+}
+
+type loggerFunc func(format string, args ...interface{})
+
+func (f loggerFunc) Printf(format string, args ...interface{}) { f(format, args...) }
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -7,16 +7,60 @@ import (
 	sq "github.com/Masterminds/squirrel"
 )
 
+var (
+	// updateRe is a plain regex, not tokenizer-aware like ParseSelect: a SET
+	// value literal that itself contains the word "where" (e.g. SET note =
+	// 'some where value') truncates the captured SET clause at the
+	// literal's "where" instead of the statement's real WHERE boundary.
+	// This is a known limitation, left unfixed for now since sqlc-generated
+	// UPDATEs use placeholders rather than literals, and UpdateBuilderFromStmt
+	// only consumes the table name from Parse today, not the SET columns.
+	updateRe = regexp.MustCompile(`(?is)^\s*update\s+([^\s;]+)\s+set\s+(.*?)(?:\s+where\s+.*)?;?\s*$`)
+	deleteRe = regexp.MustCompile(`(?is)^\s*delete\s+from\s+([^\s;]+)`)
+	insertRe = regexp.MustCompile(`(?is)^\s*insert\s+into\s+([^\s(]+)\s*\(([^)]*)\)`)
+)
+
+// Parse recognizes a SELECT, UPDATE, DELETE, or INSERT statement and
+// extracts its table and the columns relevant to building a dynamic
+// replacement with squirrel: the projected columns for SELECT, the SET
+// columns for UPDATE, none for DELETE, and the target columns for INSERT.
+//
+// For SELECT this is a thin adapter over ParseSelect, which exposes the
+// full parsed structure (CTEs, table alias, joins) for callers that need
+// more than a flat table/columns pair.
 func Parse(stmt string) (table string, columns []string) {
-	stmt = stripComments(stmt)
+	if ps, ok := ParseSelect(stmt); ok {
+		table = ps.Table
+		if ps.Alias != "" {
+			table += " " + ps.Alias
+		}
+		for _, col := range ps.Columns {
+			text := col.Expr
+			if col.Alias != "" {
+				text += " AS " + col.Alias
+			}
+			columns = append(columns, text)
+		}
+		return table, columns
+	}
 
-	re := regexp.MustCompile(`(?is)^\s*select\s+(.*?)\s+from\s+([^\s;]+)`)
-	matches := re.FindStringSubmatch(stmt)
-	if len(matches) < 3 {
-		return "", nil
+	stmt = stripComments(stmt)
+	if m := updateRe.FindStringSubmatch(stmt); len(m) >= 3 {
+		return m[1], setColumns(m[2])
 	}
+	if m := deleteRe.FindStringSubmatch(stmt); len(m) >= 2 {
+		return m[1], nil
+	}
+	if m := insertRe.FindStringSubmatch(stmt); len(m) >= 3 {
+		return m[1], splitColumns(m[2])
+	}
+	return "", nil
+}
 
-	for col := range strings.SplitSeq(matches[1], ",") {
+// splitColumns splits a comma-separated column (or expression) list,
+// trimming whitespace and surrounding quotes from each entry.
+func splitColumns(list string) (columns []string) {
+	for col := range strings.SplitSeq(list, ",") {
 		col = strings.TrimSpace(col)
 		col = unquote(col)
 		if col == "" {
@@ -24,10 +68,25 @@ func Parse(stmt string) (table string, columns []string) {
 		}
 		columns = append(columns, col)
 	}
+	return columns
+}
 
-	table = strings.TrimSpace(matches[2])
-	table = strings.TrimSuffix(table, ";")
-	return table, columns
+// setColumns extracts the column names being assigned in an UPDATE's SET
+// clause, e.g. "name = ?, age = ?" -> ["name", "age"].
+func setColumns(set string) (columns []string) {
+	for assignment := range strings.SplitSeq(set, ",") {
+		col, _, ok := strings.Cut(assignment, "=")
+		if !ok {
+			continue
+		}
+		col = strings.TrimSpace(col)
+		col = unquote(col)
+		if col == "" {
+			continue
+		}
+		columns = append(columns, col)
+	}
+	return columns
 }
 
 func SelectBuilderFromStmt(stmt string) sq.SelectBuilder {
@@ -35,6 +94,39 @@ func SelectBuilderFromStmt(stmt string) sq.SelectBuilder {
 	return sq.Select(columns...).From(table)
 }
 
+// UpdateBuilderFromStmt parses the target table out of an UPDATE statement
+// and returns a bare sq.UpdateBuilder for it. The caller is expected to add
+// the dynamic Set/Where clauses, since the values being assigned are only
+// known at call time.
+func UpdateBuilderFromStmt(stmt string) sq.UpdateBuilder {
+	table, _ := Parse(stmt)
+	return sq.Update(table)
+}
+
+// DeleteBuilderFromStmt parses the target table out of a DELETE statement
+// and returns a bare sq.DeleteBuilder for it. The caller is expected to add
+// the dynamic Where clause.
+func DeleteBuilderFromStmt(stmt string) sq.DeleteBuilder {
+	table, _ := Parse(stmt)
+	return sq.Delete(table)
+}
+
+// InsertBuilderFromStmt parses the target table and column list out of an
+// INSERT statement and returns an sq.InsertBuilder pre-populated with those
+// columns. The caller is expected to add the dynamic Values.
+func InsertBuilderFromStmt(stmt string) sq.InsertBuilder {
+	table, columns := Parse(stmt)
+	return sq.Insert(table).Columns(columns...)
+}
+
+// SelectBuilderFromStmtWithPlaceholder behaves like SelectBuilderFromStmt
+// but also pre-configures the returned builder's own PlaceholderFormat, so
+// raw SQL fragments added by user callbacks (e.g. sb.Where("extra ? = ?"))
+// are rendered in the same dialect the final query is rebound to.
+func SelectBuilderFromStmtWithPlaceholder(stmt string, pf sq.PlaceholderFormat) sq.SelectBuilder {
+	return SelectBuilderFromStmt(stmt).PlaceholderFormat(pf)
+}
+
 // stripComments removes all SQL comments from the statement:
 // - Single-line comments: `-- comment`
 // - Multi-line comments: `/* comment */`
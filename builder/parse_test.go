@@ -3,6 +3,8 @@ package builder
 import (
 	"reflect"
 	"testing"
+
+	sq "github.com/Masterminds/squirrel"
 )
 
 func TestParse(t *testing.T) {
@@ -33,7 +35,7 @@ func TestParse(t *testing.T) {
 		},
 		{
 			name:    "no match",
-			stmt:    "update table set a=1",
+			stmt:    "CREATE TABLE users (id int);",
 			table:   "",
 			columns: nil,
 		},
@@ -58,6 +60,78 @@ email, age, created_at, updated_at FROM users
 			table:   "users",
 			columns: []string{"id", "name", "email", "age", "created_at", "updated_at"},
 		},
+		{
+			name:    "update with where",
+			stmt:    "UPDATE users SET name = ?, age = ? WHERE id = ?;",
+			table:   "users",
+			columns: []string{"name", "age"},
+		},
+		{
+			name:    "update without where",
+			stmt:    "update users set deleted_at = ?",
+			table:   "users",
+			columns: []string{"deleted_at"},
+		},
+		{
+			// Known limitation: updateRe is a plain regex, not
+			// tokenizer-aware, so a SET value literal containing the word
+			// "where" is mistaken for the statement's real WHERE boundary
+			// and truncates the SET clause early. Documented here so a fix
+			// is a deliberate change to this test, not a silent regression.
+			name:    "known limitation: SET literal containing the word where truncates the SET clause",
+			stmt:    "UPDATE users SET note = 'some where value', age = ? WHERE id = ?;",
+			table:   "users",
+			columns: []string{"note"},
+		},
+		{
+			name:    "delete simple",
+			stmt:    "DELETE FROM users WHERE id = ?;",
+			table:   "users",
+			columns: nil,
+		},
+		{
+			name:    "insert simple",
+			stmt:    "INSERT INTO users (name, age) VALUES (?, ?);",
+			table:   "users",
+			columns: []string{"name", "age"},
+		},
+		{
+			name:    "alias without AS",
+			stmt:    "SELECT id FROM users u WHERE u.id = ?",
+			table:   "users u",
+			columns: []string{"id"},
+		},
+		{
+			name:    "join is dropped from the flat table/columns pair",
+			stmt:    "SELECT a, COALESCE(b, 0) AS b, u.name FROM users u JOIN posts p ON p.user_id = u.id",
+			table:   "users u",
+			columns: []string{"a", "COALESCE(b, 0) AS b", "u.name"},
+		},
+		{
+			name:    "subquery in the select list",
+			stmt:    "SELECT id, (SELECT count(*) FROM posts p WHERE p.user_id = u.id) AS post_count FROM users u",
+			table:   "users u",
+			columns: []string{"id", "(SELECT count(*) FROM posts p WHERE p.user_id = u.id) AS post_count"},
+		},
+		{
+			name: "cte is parsed but excluded from the flat table/columns pair",
+			stmt: `WITH active AS (SELECT id FROM users WHERE deleted_at IS NULL)
+				SELECT id, name FROM active`,
+			table:   "active",
+			columns: []string{"id", "name"},
+		},
+		{
+			name:    "quoted identifiers with dots",
+			stmt:    `SELECT "u"."id", "u"."name" FROM "public"."users" AS "u"`,
+			table:   `"public"."users" u`,
+			columns: []string{`"u"."id"`, `"u"."name"`},
+		},
+		{
+			name:    "comment inside parens",
+			stmt:    `SELECT id, COALESCE(age, /* default age */ 0) AS age FROM users`,
+			table:   "users",
+			columns: []string{"id", "COALESCE(age,  0) AS age"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -74,3 +148,20 @@ email, age, created_at, updated_at FROM users
 		})
 	}
 }
+
+func TestSelectBuilderFromStmtWithPlaceholder(t *testing.T) {
+	sb := SelectBuilderFromStmtWithPlaceholder("SELECT id, name FROM users WHERE id = ?", sq.Dollar)
+
+	sql, args, err := sb.Where("id = ?", 7).ToSql()
+	if err != nil {
+		t.Fatalf("ToSql() error: %v", err)
+	}
+
+	want := "SELECT id, name FROM users WHERE id = $1"
+	if sql != want {
+		t.Fatalf("sql = %q, want %q", sql, want)
+	}
+	if !reflect.DeepEqual(args, []interface{}{7}) {
+		t.Fatalf("args = %#v, want %#v", args, []interface{}{7})
+	}
+}
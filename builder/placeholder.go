@@ -0,0 +1,117 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PlaceholderFormat rebinds the `?` placeholders produced by squirrel's
+// SelectBuilder.ToSql() into the positional (or named) syntax a specific
+// SQL dialect expects. Unlike squirrel's own sq.PlaceholderFormat, Rebind
+// walks the already-assembled SQL text once and skips over quoted string,
+// quoted identifier, and dollar-quoted boundaries, so a literal `?`
+// inside a string or quoted identifier is left alone. A bare `?` outside
+// quotes (including the Postgres jsonb `?` operator) is always rewritten.
+type PlaceholderFormat interface {
+	Rebind(sql string) (string, error)
+}
+
+// placeholderFunc renders the Nth (1-indexed) placeholder for a dialect.
+type placeholderFunc func(n int) string
+
+func (f placeholderFunc) Rebind(sql string) (string, error) {
+	return rebindPlaceholders(sql, f)
+}
+
+var (
+	// Question leaves placeholders as `?` (MySQL, SQLite).
+	Question PlaceholderFormat = placeholderFunc(func(int) string { return "?" })
+	// Dollar rewrites placeholders as `$1, $2, ...` (PostgreSQL).
+	Dollar PlaceholderFormat = placeholderFunc(func(n int) string { return fmt.Sprintf("$%d", n) })
+	// AtP rewrites placeholders as `@p1, @p2, ...` (SQL Server).
+	AtP PlaceholderFormat = placeholderFunc(func(n int) string { return fmt.Sprintf("@p%d", n) })
+	// Colon rewrites placeholders as `:1, :2, ...` (Oracle and other named-parameter dialects).
+	Colon PlaceholderFormat = placeholderFunc(func(n int) string { return fmt.Sprintf(":%d", n) })
+)
+
+// Default is the PlaceholderFormat applied by Select and SelectForQuery when
+// no WithPlaceholderFormat option is given. It defaults to Question so
+// existing callers targeting MySQL/SQLite see no change in behavior.
+var Default PlaceholderFormat = Question
+
+// rebindPlaceholders walks sql once, replacing every placeholder `?`
+// outside of a quoted string, quoted identifier, or dollar-quoted body
+// with the value produced by next.
+func rebindPlaceholders(sql string, next placeholderFunc) (string, error) {
+	var buf strings.Builder
+	buf.Grow(len(sql))
+
+	n := 0
+	for i := 0; i < len(sql); {
+		switch c := sql[i]; c {
+		case '\'', '"', '`':
+			end := skipQuoted(sql, i, c)
+			buf.WriteString(sql[i:end])
+			i = end
+		case '$':
+			if end, ok := skipDollarQuoted(sql, i); ok {
+				buf.WriteString(sql[i:end])
+				i = end
+				continue
+			}
+			buf.WriteByte(c)
+			i++
+		case '?':
+			n++
+			buf.WriteString(next(n))
+			i++
+		default:
+			buf.WriteByte(c)
+			i++
+		}
+	}
+	return buf.String(), nil
+}
+
+// skipQuoted returns the index just past the closing quote matching the
+// opening quote at sql[start], treating a doubled quote (e.g. '' or ``)
+// as an escaped quote rather than the end of the string.
+func skipQuoted(sql string, start int, quote byte) int {
+	i := start + 1
+	for i < len(sql) {
+		if sql[i] == quote {
+			if i+1 < len(sql) && sql[i+1] == quote {
+				i += 2
+				continue
+			}
+			return i + 1
+		}
+		i++
+	}
+	return i
+}
+
+// skipDollarQuoted recognizes a Postgres dollar-quoted string starting at
+// sql[start] (`$$...$$` or `$tag$...$tag$`) and returns the index just
+// past its closing delimiter. ok is false if sql[start:] is not the start
+// of a valid dollar-quote delimiter.
+func skipDollarQuoted(sql string, start int) (end int, ok bool) {
+	i := start + 1
+	for i < len(sql) && (isAlnum(sql[i]) || sql[i] == '_') {
+		i++
+	}
+	if i >= len(sql) || sql[i] != '$' {
+		return 0, false
+	}
+	tag := sql[start : i+1] // e.g. "$$" or "$tag$"
+	bodyStart := i + 1
+	closeIdx := strings.Index(sql[bodyStart:], tag)
+	if closeIdx < 0 {
+		return 0, false
+	}
+	return bodyStart + closeIdx + len(tag), true
+}
+
+func isAlnum(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
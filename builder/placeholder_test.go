@@ -0,0 +1,85 @@
+package builder
+
+import "testing"
+
+func TestRebindPlaceholders(t *testing.T) {
+	tests := []struct {
+		name string
+		pf   PlaceholderFormat
+		sql  string
+		want string
+	}{
+		{
+			name: "question is a no-op",
+			pf:   Question,
+			sql:  "SELECT * FROM t WHERE a = ? AND b = ?",
+			want: "SELECT * FROM t WHERE a = ? AND b = ?",
+		},
+		{
+			name: "dollar",
+			pf:   Dollar,
+			sql:  "SELECT * FROM t WHERE a = ? AND b = ?",
+			want: "SELECT * FROM t WHERE a = $1 AND b = $2",
+		},
+		{
+			name: "atp",
+			pf:   AtP,
+			sql:  "SELECT * FROM t WHERE a = ? AND b = ?",
+			want: "SELECT * FROM t WHERE a = @p1 AND b = @p2",
+		},
+		{
+			name: "colon",
+			pf:   Colon,
+			sql:  "SELECT * FROM t WHERE a = ? AND b = ?",
+			want: "SELECT * FROM t WHERE a = :1 AND b = :2",
+		},
+		{
+			name: "single-quoted literal containing a question mark is untouched",
+			pf:   Dollar,
+			sql:  "SELECT * FROM t WHERE a = ? AND note = 'is this ok?'",
+			want: "SELECT * FROM t WHERE a = $1 AND note = 'is this ok?'",
+		},
+		{
+			name: "escaped single quote inside literal",
+			pf:   Dollar,
+			sql:  "SELECT * FROM t WHERE a = ? AND note = 'it''s ?'",
+			want: "SELECT * FROM t WHERE a = $1 AND note = 'it''s ?'",
+		},
+		{
+			name: "double-quoted identifier containing a question mark",
+			pf:   Dollar,
+			sql:  `SELECT "weird?col" FROM t WHERE a = ?`,
+			want: `SELECT "weird?col" FROM t WHERE a = $1`,
+		},
+		{
+			name: "backtick-quoted identifier containing a question mark",
+			pf:   Dollar,
+			sql:  "SELECT `weird?col` FROM t WHERE a = ?",
+			want: "SELECT `weird?col` FROM t WHERE a = $1",
+		},
+		{
+			name: "dollar-quoted function body is untouched",
+			pf:   Dollar,
+			sql:  "SELECT * FROM t WHERE a = ? AND f() = $$begin return 1 ? 2 end$$",
+			want: "SELECT * FROM t WHERE a = $1 AND f() = $$begin return 1 ? 2 end$$",
+		},
+		{
+			name: "tagged dollar-quoted body is untouched",
+			pf:   Dollar,
+			sql:  "SELECT * FROM t WHERE a = ? AND f() = $tag$has a ? inside$tag$ AND b = ?",
+			want: "SELECT * FROM t WHERE a = $1 AND f() = $tag$has a ? inside$tag$ AND b = $2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.pf.Rebind(tt.sql)
+			if err != nil {
+				t.Fatalf("Rebind() error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("Rebind() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package builder
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// Router dispatches each intercepted DBTX call to a different builder per
+// sqlc query, so a single wrapped DBTX can serve several dynamic queries
+// within one request-scoped transaction instead of stacking one wrapper
+// per query. A query with nothing registered for it passes straight
+// through to the raw DBTX.
+type Router struct {
+	raw DBTX
+	pf  PlaceholderFormat
+
+	selects   map[string]sq.SelectBuilder
+	selectFns map[string]func(ctx context.Context) (sq.SelectBuilder, error)
+	updates   map[string]sq.UpdateBuilder
+	deletes   map[string]sq.DeleteBuilder
+	inserts   map[string]sq.InsertBuilder
+}
+
+// NewRouter wraps raw in a Router with no queries registered.
+func NewRouter(raw DBTX) *Router {
+	return &Router{raw: raw, pf: Default}
+}
+
+// WithPlaceholderFormat sets the PlaceholderFormat r rebinds every
+// dispatched builder's SQL into before it reaches raw. Defaults to the
+// package-level Default.
+func (r *Router) WithPlaceholderFormat(pf PlaceholderFormat) *Router {
+	r.pf = pf
+	return r
+}
+
+// Register dispatches query to sb whenever QueryContext or
+// QueryRowContext is called with that exact query text.
+func (r *Router) Register(query string, sb sq.SelectBuilder) {
+	if r.selects == nil {
+		r.selects = make(map[string]sq.SelectBuilder)
+	}
+	r.selects[query] = sb
+}
+
+// RegisterFunc is like Register, but builds the SelectBuilder fresh on
+// every matching call, so it can depend on request-scoped state carried
+// in ctx instead of being fixed up front.
+func (r *Router) RegisterFunc(query string, fn func(ctx context.Context) (sq.SelectBuilder, error)) {
+	if r.selectFns == nil {
+		r.selectFns = make(map[string]func(ctx context.Context) (sq.SelectBuilder, error))
+	}
+	r.selectFns[query] = fn
+}
+
+// RegisterUpdate dispatches query to ub whenever ExecContext is called
+// with that exact query text.
+func (r *Router) RegisterUpdate(query string, ub sq.UpdateBuilder) {
+	if r.updates == nil {
+		r.updates = make(map[string]sq.UpdateBuilder)
+	}
+	r.updates[query] = ub
+}
+
+// RegisterDelete dispatches query to db whenever ExecContext is called
+// with that exact query text.
+func (r *Router) RegisterDelete(query string, db sq.DeleteBuilder) {
+	if r.deletes == nil {
+		r.deletes = make(map[string]sq.DeleteBuilder)
+	}
+	r.deletes[query] = db
+}
+
+// RegisterInsert dispatches query to ib whenever ExecContext is called
+// with that exact query text.
+func (r *Router) RegisterInsert(query string, ib sq.InsertBuilder) {
+	if r.inserts == nil {
+		r.inserts = make(map[string]sq.InsertBuilder)
+	}
+	r.inserts[query] = ib
+}
+
+func (r *Router) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return r.raw.PrepareContext(ctx, query)
+}
+
+func (r *Router) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	var eb execBuilder
+	if ub, ok := r.updates[query]; ok {
+		eb = ub
+	} else if db, ok := r.deletes[query]; ok {
+		eb = db
+	} else if ib, ok := r.inserts[query]; ok {
+		eb = ib
+	} else {
+		return r.raw.ExecContext(ctx, query, args...)
+	}
+
+	overriddenQuery, overriddenArgs, err := eb.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	overriddenQuery, err = r.pf.Rebind(overriddenQuery)
+	if err != nil {
+		return nil, err
+	}
+	return r.raw.ExecContext(ctx, overriddenQuery, overriddenArgs...)
+}
+
+func (r *Router) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	sb, ok, err := r.selectBuilder(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return r.raw.QueryContext(ctx, query, args...)
+	}
+
+	overriddenQuery, overriddenArgs, err := sb.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	overriddenQuery, err = r.pf.Rebind(overriddenQuery)
+	if err != nil {
+		return nil, err
+	}
+	return r.raw.QueryContext(ctx, overriddenQuery, overriddenArgs...)
+}
+
+func (r *Router) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	sb, ok, err := r.selectBuilder(ctx, query)
+	if err != nil || !ok {
+		return r.raw.QueryRowContext(ctx, query, args...)
+	}
+
+	overriddenQuery, overriddenArgs, err := sb.ToSql()
+	if err != nil {
+		return r.raw.QueryRowContext(ctx, query, args...)
+	}
+	overriddenQuery, err = r.pf.Rebind(overriddenQuery)
+	if err != nil {
+		return r.raw.QueryRowContext(ctx, query, args...)
+	}
+	return r.raw.QueryRowContext(ctx, overriddenQuery, overriddenArgs...)
+}
+
+// selectBuilder resolves query to a registered SelectBuilder, either
+// fixed (Register) or freshly built (RegisterFunc). ok is false if
+// nothing is registered for query.
+func (r *Router) selectBuilder(ctx context.Context, query string) (sb sq.SelectBuilder, ok bool, err error) {
+	if sb, ok = r.selects[query]; ok {
+		return sb, true, nil
+	}
+	if fn, ok := r.selectFns[query]; ok {
+		sb, err := fn(ctx)
+		return sb, true, err
+	}
+	return sq.SelectBuilder{}, false, nil
+}
@@ -0,0 +1,95 @@
+package builder
+
+import (
+	"context"
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+func TestRouter_DispatchesRegisteredSelects(t *testing.T) {
+	ctx := context.Background()
+	raw := &recordDB{}
+	r := NewRouter(raw)
+
+	usersSB := sq.Select("*").From("users").Where(sq.Eq{"id": 1})
+	r.Register("list-users", usersSB)
+
+	postsSB := sq.Select("*").From("posts").Where(sq.Eq{"user_id": 2})
+	r.RegisterFunc("list-posts", func(context.Context) (sq.SelectBuilder, error) {
+		return postsSB, nil
+	})
+
+	if _, err := r.QueryContext(ctx, "list-users"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantQuery, _, _ := usersSB.ToSql()
+	if raw.lastQuery != wantQuery {
+		t.Fatalf("query = %q, want %q", raw.lastQuery, wantQuery)
+	}
+
+	if _, err := r.QueryContext(ctx, "list-posts"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantQuery, _, _ = postsSB.ToSql()
+	if raw.lastQuery != wantQuery {
+		t.Fatalf("query = %q, want %q", raw.lastQuery, wantQuery)
+	}
+
+	// Unregistered queries pass through unchanged.
+	if _, err := r.QueryContext(ctx, "other", 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if raw.lastQuery != "other" {
+		t.Fatalf("query = %q, want %q", raw.lastQuery, "other")
+	}
+}
+
+func TestRouter_DispatchesRegisteredMutations(t *testing.T) {
+	ctx := context.Background()
+	raw := &recordExecDB{}
+	r := NewRouter(raw)
+
+	ub := sq.Update("users").Set("name", "bob").Where(sq.Eq{"id": 1})
+	r.RegisterUpdate("update-user", ub)
+
+	db := sq.Delete("users").Where(sq.Eq{"id": 1})
+	r.RegisterDelete("delete-user", db)
+
+	ib := sq.Insert("users").Columns("name").Values("bob")
+	r.RegisterInsert("insert-user", ib)
+
+	if _, err := r.ExecContext(ctx, "update-user"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantQuery, _, _ := ub.ToSql()
+	if raw.lastQuery != wantQuery {
+		t.Fatalf("query = %q, want %q", raw.lastQuery, wantQuery)
+	}
+
+	if _, err := r.ExecContext(ctx, "delete-user"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantQuery, _, _ = db.ToSql()
+	if raw.lastQuery != wantQuery {
+		t.Fatalf("query = %q, want %q", raw.lastQuery, wantQuery)
+	}
+
+	if _, err := r.ExecContext(ctx, "insert-user"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantQuery, _, _ = ib.ToSql()
+	if raw.lastQuery != wantQuery {
+		t.Fatalf("query = %q, want %q", raw.lastQuery, wantQuery)
+	}
+
+	// Unregistered queries pass through unchanged.
+	if _, err := r.ExecContext(ctx, "other", 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if raw.lastQuery != "other" {
+		t.Fatalf("query = %q, want %q", raw.lastQuery, "other")
+	}
+}
+
+var _ DBTX = (*Router)(nil)
@@ -11,6 +11,8 @@ type selector struct {
 	raw           DBTX
 	expectedQuery string
 	sb            sq.SelectBuilder
+	pf            PlaceholderFormat
+	expandIn      bool
 }
 
 func (r *selector) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
@@ -33,6 +35,16 @@ func (r *selector) QueryContext(ctx context.Context, query string, args ...inter
 	if err != nil {
 		return nil, err
 	}
+	if r.expandIn {
+		overriddenQuery, overriddenArgs, err = ExpandIn(overriddenQuery, overriddenArgs...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	overriddenQuery, err = r.pf.Rebind(overriddenQuery)
+	if err != nil {
+		return nil, err
+	}
 	return r.raw.QueryContext(ctx, overriddenQuery, overriddenArgs...)
 }
 
@@ -46,5 +58,15 @@ func (r *selector) QueryRowContext(ctx context.Context, query string, args ...in
 		// Can't return an error from QueryRowContext; fall back to raw behavior.
 		return r.raw.QueryRowContext(ctx, query, args...)
 	}
+	if r.expandIn {
+		overriddenQuery, overriddenArgs, err = ExpandIn(overriddenQuery, overriddenArgs...)
+		if err != nil {
+			return r.raw.QueryRowContext(ctx, query, args...)
+		}
+	}
+	overriddenQuery, err = r.pf.Rebind(overriddenQuery)
+	if err != nil {
+		return r.raw.QueryRowContext(ctx, query, args...)
+	}
 	return r.raw.QueryRowContext(ctx, overriddenQuery, overriddenArgs...)
 }
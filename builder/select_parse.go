@@ -0,0 +1,301 @@
+package builder
+
+import "strings"
+
+// Column is a single projected expression in a SELECT's column list,
+// e.g. "COALESCE(b, 0) AS b" parses to {Expr: "COALESCE(b, 0)", Alias: "b"}.
+type Column struct {
+	Expr  string
+	Alias string
+}
+
+// ParsedSelect is the structured result of parsing a SELECT statement.
+// CTEs holds the raw "name AS (...)" text of any statements in a leading
+// WITH clause. Table/Alias describe the statement's primary FROM target
+// (the first table reference after the main SELECT's FROM; tables inside
+// CTE bodies are not considered, and a CTE name used as that target is
+// returned as-is), and Joins
+// holds the raw text of any JOIN clauses following it, in source order.
+type ParsedSelect struct {
+	CTEs    []string
+	Table   string
+	Alias   string
+	Columns []Column
+	Joins   []string
+}
+
+var joinQualifierKeywords = map[string]bool{
+	"inner": true, "left": true, "right": true, "full": true, "cross": true, "outer": true,
+}
+
+var tableBoundaryKeywords = map[string]bool{
+	"join": true, "inner": true, "left": true, "right": true, "full": true, "cross": true, "outer": true,
+	"where": true, "group": true, "order": true, "limit": true, "having": true, "union": true, "on": true,
+}
+
+// ParseSelect parses a (possibly CTE-prefixed) SELECT statement into its
+// constituent parts. ok is false if stmt isn't recognized as a SELECT.
+func ParseSelect(stmt string) (ps *ParsedSelect, ok bool) {
+	stripped := stripComments(stmt)
+	toks := tokenize(stripped)
+	pos := 0
+	ps = &ParsedSelect{}
+
+	if pos < len(toks) && toks[pos].keyword("with") {
+		ctes, next, cteOK := parseCTEs(stripped, toks, pos+1)
+		if !cteOK {
+			return nil, false
+		}
+		ps.CTEs = ctes
+		pos = next
+	}
+
+	if pos >= len(toks) || !toks[pos].keyword("select") {
+		return nil, false
+	}
+	pos++
+
+	fromIdx, found := findTopLevelKeyword(toks, pos, "from")
+	if !found {
+		return nil, false
+	}
+	ps.Columns = parseColumns(stripped, toks, pos, fromIdx)
+	pos = fromIdx + 1
+
+	table, alias, next, tableOK := parseTableRef(stripped, toks, pos)
+	if !tableOK {
+		return nil, false
+	}
+	ps.Table, ps.Alias = table, alias
+	ps.Joins, _ = parseJoins(stripped, toks, next)
+
+	return ps, true
+}
+
+// findTopLevelKeyword returns the index of the first token at paren depth
+// 0 matching kw, starting from start.
+func findTopLevelKeyword(toks []token, start int, kw string) (int, bool) {
+	depth := 0
+	for i := start; i < len(toks); i++ {
+		switch toks[i].text {
+		case "(":
+			depth++
+		case ")":
+			depth--
+		}
+		if depth == 0 && toks[i].keyword(kw) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// splitTopLevel splits toks[start:end] into segments at commas found at
+// paren depth 0, so commas inside function calls or subqueries don't
+// split a single column expression.
+func splitTopLevel(toks []token, start, end int) [][2]int {
+	var segs [][2]int
+	depth := 0
+	segStart := start
+	for i := start; i < end; i++ {
+		switch toks[i].text {
+		case "(":
+			depth++
+		case ")":
+			depth--
+		case ",":
+			if depth == 0 {
+				segs = append(segs, [2]int{segStart, i})
+				segStart = i + 1
+			}
+		}
+	}
+	return append(segs, [2]int{segStart, end})
+}
+
+func parseColumns(stmt string, toks []token, start, end int) (cols []Column) {
+	for _, seg := range splitTopLevel(toks, start, end) {
+		s, e := seg[0], seg[1]
+		if s >= e {
+			continue
+		}
+		cols = append(cols, parseColumn(stmt, toks, s, e))
+	}
+	return cols
+}
+
+// parseColumn splits a single column-list entry into its expression and
+// optional alias, recognizing both an explicit "expr AS alias" and an
+// implicit "expr alias" (a trailing bare identifier that isn't part of a
+// dotted reference).
+func parseColumn(stmt string, toks []token, s, e int) Column {
+	if e-s == 1 {
+		return Column{Expr: unquote(toks[s].text)}
+	}
+
+	depth := 0
+	for i := s; i < e; i++ {
+		switch toks[i].text {
+		case "(":
+			depth++
+		case ")":
+			depth--
+		}
+		if depth == 0 && toks[i].keyword("as") {
+			expr := strings.TrimSpace(stmt[toks[s].start:toks[i-1].end])
+			if i+1 < e {
+				return Column{Expr: expr, Alias: unquote(strings.TrimSpace(stmt[toks[i+1].start:toks[e-1].end]))}
+			}
+			return Column{Expr: expr}
+		}
+	}
+
+	raw := strings.TrimSpace(stmt[toks[s].start:toks[e-1].end])
+	if last := toks[e-1]; isBareIdent(last) && toks[e-2].text != "." {
+		expr := strings.TrimSpace(stmt[toks[s].start:toks[e-2].end])
+		return Column{Expr: expr, Alias: unquote(last.text)}
+	}
+	return Column{Expr: raw}
+}
+
+// parseTableRef parses the table reference (and its optional alias)
+// immediately following FROM, stopping before any JOIN/WHERE/GROUP/ORDER/
+// etc. that follows it.
+func parseTableRef(stmt string, toks []token, pos int) (table, alias string, next int, ok bool) {
+	if pos >= len(toks) || !isBareIdent(toks[pos]) {
+		return "", "", pos, false
+	}
+	start := pos
+	pos++
+	for pos+1 < len(toks) && toks[pos].text == "." && isBareIdent(toks[pos+1]) {
+		pos += 2
+	}
+	table = strings.TrimSpace(stmt[toks[start].start:toks[pos-1].end])
+
+	if pos < len(toks) && toks[pos].keyword("as") {
+		pos++
+		if pos < len(toks) && isBareIdent(toks[pos]) {
+			alias = unquote(toks[pos].text)
+			pos++
+		}
+		return table, alias, pos, true
+	}
+	if pos < len(toks) && isBareIdent(toks[pos]) && !tableBoundaryKeywords[strings.ToLower(toks[pos].text)] {
+		alias = unquote(toks[pos].text)
+		pos++
+	}
+	return table, alias, pos, true
+}
+
+// parseJoins collects the raw text of each JOIN clause starting at pos,
+// stopping at the next WHERE/GROUP/ORDER/LIMIT/HAVING/UNION or the end of
+// the statement.
+func parseJoins(stmt string, toks []token, pos int) (joins []string, next int) {
+	for pos < len(toks) && isJoinStart(toks, pos) {
+		start := pos
+		depth := 0
+		pos = joinHeaderEnd(toks, pos)
+		for pos < len(toks) {
+			t := toks[pos]
+			switch t.text {
+			case "(":
+				depth++
+			case ")":
+				depth--
+			}
+			if depth == 0 {
+				lk := strings.ToLower(t.text)
+				if isJoinStart(toks, pos) || lk == "where" || lk == "group" || lk == "order" || lk == "limit" || lk == "having" || lk == "union" {
+					break
+				}
+			}
+			pos++
+		}
+		joins = append(joins, strings.TrimSpace(stmt[toks[start].start:toks[pos-1].end]))
+	}
+	return joins, pos
+}
+
+// isJoinStart reports whether toks[pos] begins a (possibly compound) JOIN
+// keyword: a bare "join", or a run of qualifiers ("left", "right", "full",
+// "inner", "cross", "outer") immediately followed by "join", e.g. "left
+// join" or "right outer join".
+func isJoinStart(toks []token, pos int) bool {
+	for i := pos; i < len(toks); i++ {
+		switch strings.ToLower(toks[i].text) {
+		case "join":
+			return true
+		default:
+			if !joinQualifierKeywords[strings.ToLower(toks[i].text)] {
+				return false
+			}
+		}
+	}
+	return false
+}
+
+// joinHeaderEnd returns the index just past the "join" token of the
+// compound JOIN keyword starting at pos, assuming isJoinStart(toks, pos).
+func joinHeaderEnd(toks []token, pos int) int {
+	for i := pos; i < len(toks); i++ {
+		if strings.ToLower(toks[i].text) == "join" {
+			return i + 1
+		}
+	}
+	return pos + 1
+}
+
+// parseCTEs parses the comma-separated list of CTEs following WITH, up to
+// (but not including) the final SELECT. Each entry is returned as its raw
+// "name AS (...)" text.
+func parseCTEs(stmt string, toks []token, pos int) (ctes []string, next int, ok bool) {
+	for {
+		if pos >= len(toks) || !isBareIdent(toks[pos]) {
+			return nil, pos, false
+		}
+		nameStart := pos
+		pos++
+
+		if pos < len(toks) && toks[pos].text == "(" {
+			pos = skipParens(toks, pos)
+		}
+
+		if pos >= len(toks) || !toks[pos].keyword("as") {
+			return nil, pos, false
+		}
+		pos++
+		if pos >= len(toks) || toks[pos].text != "(" {
+			return nil, pos, false
+		}
+		pos = skipParens(toks, pos)
+
+		ctes = append(ctes, strings.TrimSpace(stmt[toks[nameStart].start:toks[pos-1].end]))
+
+		if pos < len(toks) && toks[pos].text == "," {
+			pos++
+			continue
+		}
+		return ctes, pos, true
+	}
+}
+
+// skipParens advances past the parenthesized group starting at
+// toks[start] (which must be "("), returning the index just past its
+// matching ")".
+func skipParens(toks []token, start int) int {
+	depth := 0
+	i := start
+	for i < len(toks) {
+		switch toks[i].text {
+		case "(":
+			depth++
+		case ")":
+			depth--
+		}
+		i++
+		if depth == 0 {
+			break
+		}
+	}
+	return i
+}
@@ -0,0 +1,45 @@
+package builder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSelect(t *testing.T) {
+	ps, ok := ParseSelect(`
+		WITH active AS (SELECT id FROM users WHERE deleted_at IS NULL)
+		SELECT a, COALESCE(b, 0) AS b, u.name
+		FROM users u
+		JOIN posts p ON p.user_id = u.id
+		LEFT JOIN comments c ON c.post_id = p.id
+		WHERE u.id = ?
+	`)
+	if !ok {
+		t.Fatalf("ParseSelect() ok = false, want true")
+	}
+
+	if want := []string{"active AS (SELECT id FROM users WHERE deleted_at IS NULL)"}; !reflect.DeepEqual(ps.CTEs, want) {
+		t.Fatalf("CTEs = %#v, want %#v", ps.CTEs, want)
+	}
+	if ps.Table != "users" || ps.Alias != "u" {
+		t.Fatalf("Table/Alias = %q/%q, want %q/%q", ps.Table, ps.Alias, "users", "u")
+	}
+	wantJoins := []string{"JOIN posts p ON p.user_id = u.id", "LEFT JOIN comments c ON c.post_id = p.id"}
+	if !reflect.DeepEqual(ps.Joins, wantJoins) {
+		t.Fatalf("Joins = %#v, want %#v", ps.Joins, wantJoins)
+	}
+	wantColumns := []Column{
+		{Expr: "a"},
+		{Expr: "COALESCE(b, 0)", Alias: "b"},
+		{Expr: "u.name"},
+	}
+	if !reflect.DeepEqual(ps.Columns, wantColumns) {
+		t.Fatalf("Columns = %#v, want %#v", ps.Columns, wantColumns)
+	}
+}
+
+func TestParseSelect_NotASelect(t *testing.T) {
+	if _, ok := ParseSelect("DELETE FROM users WHERE id = ?"); ok {
+		t.Fatalf("ParseSelect() ok = true for a DELETE statement, want false")
+	}
+}
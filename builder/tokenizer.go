@@ -0,0 +1,71 @@
+package builder
+
+import "strings"
+
+// token is a lexical unit of a SQL statement. It tracks its byte offsets
+// in the original (comment-stripped) statement so callers can slice out
+// verbatim source text instead of reassembling it from token text.
+type token struct {
+	text       string
+	start, end int
+}
+
+// tokenize splits stmt into words/identifiers, quoted strings (including
+// dollar-quoted bodies), and single-character punctuation, skipping
+// whitespace. It doesn't validate SQL syntax; callers walk the resulting
+// token list to locate keywords and balance parentheses.
+func tokenize(stmt string) []token {
+	var toks []token
+	i, n := 0, len(stmt)
+	for i < n {
+		c := stmt[i]
+		switch {
+		case isSpace(c):
+			i++
+		case c == '\'' || c == '"' || c == '`':
+			end := skipQuoted(stmt, i, c)
+			toks = append(toks, token{stmt[i:end], i, end})
+			i = end
+		case c == '$':
+			if end, ok := skipDollarQuoted(stmt, i); ok {
+				toks = append(toks, token{stmt[i:end], i, end})
+				i = end
+				continue
+			}
+			toks = append(toks, token{"$", i, i + 1})
+			i++
+		case isIdentStart(c):
+			j := i + 1
+			for j < n && isIdentPart(stmt[j]) {
+				j++
+			}
+			toks = append(toks, token{stmt[i:j], i, j})
+			i = j
+		default:
+			toks = append(toks, token{stmt[i : i+1], i, i + 1})
+			i++
+		}
+	}
+	return toks
+}
+
+func isSpace(c byte) bool { return c == ' ' || c == '\t' || c == '\n' || c == '\r' }
+
+func isIdentStart(c byte) bool { return isAlnum(c) || c == '_' }
+
+func isIdentPart(c byte) bool { return isAlnum(c) || c == '_' }
+
+// keyword reports whether t's text equals kw, case-insensitively.
+func (t token) keyword(kw string) bool {
+	return strings.EqualFold(t.text, kw)
+}
+
+// isBareIdent reports whether t can stand on its own as an identifier:
+// a word, or a quoted identifier/string.
+func isBareIdent(t token) bool {
+	if t.text == "" {
+		return false
+	}
+	c := t.text[0]
+	return isIdentStart(c) || c == '"' || c == '`' || c == '\''
+}
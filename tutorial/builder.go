@@ -15,8 +15,34 @@ var (
 	listUserPostsBuilder = builder.SelectBuilderFromStmt(listUserPosts).
 				Where(sq.Eq{"deleted_at": nil}). // filter out deleted user posts
 				Limit(500)                       // limit the number of user posts to 500 default to avoid overwhelming the database
+
+	updateUserBuilder = builder.UpdateBuilderFromStmt(updateUser)
+	deleteUserBuilder = builder.DeleteBuilderFromStmt(deleteUser)
+	insertUserBuilder = builder.InsertBuilderFromStmt(insertUser)
 )
 
+// NewRouter wraps db in a *builder.Router with ListUsers and ListUserPosts
+// pre-registered, so a caller needing both dynamic queries in a single
+// request-scoped transaction only wraps db once instead of stacking one
+// Select wrapper per query.
+func NewRouter(db DBTX, userFn func(sb sq.SelectBuilder) sq.SelectBuilder, postFn func(sb sq.SelectBuilder) sq.SelectBuilder) *builder.Router {
+	r := builder.NewRouter(db)
+
+	sb := listUsersBuilder
+	if userFn != nil {
+		sb = userFn(sb)
+	}
+	r.Register(listUsers, sb)
+
+	psb := listUserPostsBuilder
+	if postFn != nil {
+		psb = postFn(psb)
+	}
+	r.Register(listUserPosts, psb)
+
+	return r
+}
+
 func ListUsers(ctx context.Context, db DBTX, fn func(sb sq.SelectBuilder) sq.SelectBuilder) ([]User, error) {
 	sb := listUsersBuilder
 	if fn != nil {
@@ -71,3 +97,48 @@ func FindUserPost(ctx context.Context, db DBTX, fn func(sb sq.SelectBuilder) sq.
 	}
 	return userPosts[0], nil
 }
+
+// UpdateUsers lets fn add the dynamic Set/Where clauses (e.g. a tenant
+// filter) to a base UPDATE users builder, then runs it through db and
+// reports how many rows were affected.
+func UpdateUsers(ctx context.Context, db DBTX, fn func(ub sq.UpdateBuilder) sq.UpdateBuilder) (int64, error) {
+	ub := updateUserBuilder
+	if fn != nil {
+		ub = fn(ub)
+	}
+	res, err := builder.Update(db, ub).ExecContext(ctx, updateUser)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// DeleteUsers lets fn add the dynamic Where clause (e.g. a soft-delete
+// filter) to a base DELETE FROM users builder, then runs it through db and
+// reports how many rows were affected.
+func DeleteUsers(ctx context.Context, db DBTX, fn func(db sq.DeleteBuilder) sq.DeleteBuilder) (int64, error) {
+	dbld := deleteUserBuilder
+	if fn != nil {
+		dbld = fn(dbld)
+	}
+	res, err := builder.Delete(db, dbld).ExecContext(ctx, deleteUser)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// InsertUsers lets fn add the dynamic Values to a base INSERT INTO users
+// builder, then runs it through db and reports how many rows were
+// inserted.
+func InsertUsers(ctx context.Context, db DBTX, fn func(ib sq.InsertBuilder) sq.InsertBuilder) (int64, error) {
+	ib := insertUserBuilder
+	if fn != nil {
+		ib = fn(ib)
+	}
+	res, err := builder.Insert(db, ib).ExecContext(ctx, insertUser)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}